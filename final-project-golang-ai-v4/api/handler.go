@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrEmptyQuery is returned by a Resolver when req.Query is blank.
+var ErrEmptyQuery = errors.New("query cannot be empty")
+
+// Resolver runs a QueryRequest against whatever provider, table and
+// conversation history the caller has wired up, returning a typed
+// QueryResponse. It's an interface so this package doesn't need to know
+// about the concrete provider/table/session types living in main.
+type Resolver interface {
+	Resolve(ctx context.Context, sessionID string, req QueryRequest) (QueryResponse, error)
+}
+
+// HandleQuery is the dedicated JSON API handler behind /api/v1/query: it
+// decodes a QueryRequest body, resolves it via resolver, and writes the
+// QueryResponse (or a typed error) as JSON. sessionID and statusForErr are
+// supplied by the caller so this package stays independent of cookie-backed
+// sessions and application-specific error kinds.
+func HandleQuery(c *gin.Context, resolver Resolver, sessionID func(*gin.Context) (string, error), statusForErr func(error) int) {
+	var req QueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sid, err := sessionID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error establishing session: " + err.Error()})
+		return
+	}
+
+	resp, err := resolver.Resolve(c.Request.Context(), sid, req)
+	if err != nil {
+		c.JSON(statusForErr(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,22 @@
+// Package api holds the typed request/response structs and handler for the
+// JSON API exposed alongside the HTML chatbot, kept independent of the
+// concrete provider/table/session types the main package wires it to.
+package api
+
+// QueryRequest is the typed request body accepted by /api/v1/query, mirroring
+// the form fields the HTML handler already reads off the HTML form.
+type QueryRequest struct {
+	Query       string `json:"query"`
+	TableID     string `json:"table_id,omitempty"`
+	CacheBypass bool   `json:"cache_bypass,omitempty"`
+}
+
+// QueryResponse is the typed response returned by /api/v1/query and rendered
+// into the chatbot template by the HTML adapter.
+type QueryResponse struct {
+	Query       string   `json:"query"`
+	Answer      string   `json:"answer"`
+	Coordinates [][]int  `json:"coordinates,omitempty"`
+	Cells       []string `json:"cells,omitempty"`
+	Aggregator  string   `json:"aggregator,omitempty"`
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"example.com/finalproject/api"
+)
+
+// queryResolver implements api.Resolver, running a query against provider
+// using table (or the uploaded table named by req.TableID) plus the
+// session's prior turns. Both the HTML form handler and the JSON API share
+// this same implementation so the two presentations can never drift apart.
+type queryResolver struct {
+	provider      ModelProvider
+	table         map[string][]string
+	tables        *TableRegistry
+	conversations *ConversationStore
+}
+
+func (r *queryResolver) Resolve(ctx context.Context, sessionID string, req api.QueryRequest) (api.QueryResponse, error) {
+	if req.Query == "" {
+		return api.QueryResponse{}, api.ErrEmptyQuery
+	}
+
+	resolvedTable, err := resolveTable(r.tables, req.TableID, r.table)
+	if err != nil {
+		return api.QueryResponse{}, err
+	}
+
+	payload := Inputs{
+		Table:       resolvedTable,
+		Query:       req.Query,
+		History:     r.conversations.History(sessionID),
+		CacheBypass: req.CacheBypass,
+	}
+
+	response, err := r.provider.Invoke(ctx, payload)
+	if err != nil {
+		return api.QueryResponse{}, err
+	}
+
+	r.conversations.Append(sessionID, Message{Role: "user", Content: req.Query})
+	r.conversations.Append(sessionID, Message{Role: "assistant", Content: response.Answer})
+
+	return api.QueryResponse{
+		Query:       req.Query,
+		Answer:      response.Answer,
+		Coordinates: response.Coordinates,
+		Cells:       response.Cells,
+		Aggregator:  response.Aggregator,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResponseCache memoizes provider responses by a canonicalized cache key, so
+// identical (table, query) pairs skip the upstream call.
+type ResponseCache interface {
+	Get(key string) (Response, bool)
+	Set(key string, r Response, ttl time.Duration)
+	Flush()
+}
+
+// CacheKey builds a stable cache key from provider, table, query and
+// conversation history: the provider name is included so the same
+// (table, query) pair asked of two different backends never collides,
+// columns are sorted and cell values trimmed so column order and
+// whitespace don't produce different keys for the same data (row order
+// within a column still does), query is
+// lowercased with whitespace collapsed, and history is folded in turn by
+// turn so two sessions asking the same follow-up question with different
+// prior context never share a cached, context-dependent answer.
+func CacheKey(provider string, table map[string][]string, query string, history []Message) string {
+	columns := make([]string, 0, len(table))
+	for col := range table {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	var b strings.Builder
+	b.WriteString(provider)
+	b.WriteByte(0)
+	for _, col := range columns {
+		b.WriteString(col)
+		b.WriteByte(0)
+		for _, v := range table[col] {
+			b.WriteString(strings.TrimSpace(v))
+			b.WriteByte(0)
+		}
+	}
+
+	b.WriteByte(1)
+	b.WriteString(strings.Join(strings.Fields(strings.ToLower(query)), " "))
+
+	b.WriteByte(2)
+	for _, msg := range history {
+		b.WriteString(msg.Role)
+		b.WriteByte(0)
+		b.WriteString(msg.Content)
+		b.WriteByte(0)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
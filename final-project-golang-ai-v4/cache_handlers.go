@@ -0,0 +1,38 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerCacheRoutes wires DELETE /cache, which flushes the shared
+// response cache after checking the X-Admin-Token header against
+// cacheCfg's configured admin token. If caching is disabled or no admin
+// token is configured, the route responds 404/403 respectively rather than
+// silently flushing nothing.
+func registerCacheRoutes(r *gin.Engine, providers *ProviderRegistry, cacheCfg CacheConfig) {
+	r.DELETE("/cache", func(c *gin.Context) {
+		cache := providers.Cache()
+		if cache == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "caching is not enabled"})
+			return
+		}
+
+		adminToken := cacheCfg.AdminToken()
+		if adminToken == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "cache admin token is not configured"})
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(adminToken)) != 1 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "invalid admin token"})
+			return
+		}
+
+		cache.Flush()
+		c.Status(http.StatusNoContent)
+	})
+}
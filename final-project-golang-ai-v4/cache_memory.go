@@ -0,0 +1,87 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-memory ResponseCache bounded by entry count, evicting
+// the least-recently-used entry once capacity is exceeded.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   Response
+	expires time.Time
+}
+
+// NewMemoryCache creates a cache holding at most capacity entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached response for key, if present and not expired.
+func (c *MemoryCache) Get(key string) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return Response{}, false
+	}
+
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return Response{}, false
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set stores r under key with the given ttl, evicting the oldest entry if
+// the cache is over capacity.
+func (c *MemoryCache) Set(key string, r Response, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.value = r
+		entry.expires = time.Now().Add(ttl)
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&memoryCacheEntry{key: key, value: r, expires: time.Now().Add(ttl)})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
+
+// Flush empties the cache.
+func (c *MemoryCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces every key this cache writes, so Flush can scope
+// itself to entries it owns instead of touching the whole Redis database,
+// which may be shared with other services or deployments.
+const redisKeyPrefix = "chatbot:cache:"
+
+// RedisCache is a ResponseCache backed by a shared Redis instance, used
+// instead of MemoryCache when cache.backend is "redis" so the cache
+// survives restarts and is shared across replicas.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache connects to the Redis instance at addr.
+func NewRedisCache(addr string) *RedisCache {
+	return &RedisCache{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+// Get returns the cached response for key, if present.
+func (c *RedisCache) Get(key string) (Response, bool) {
+	data, err := c.client.Get(context.Background(), redisKeyPrefix+key).Bytes()
+	if err != nil {
+		return Response{}, false
+	}
+
+	var r Response
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Response{}, false
+	}
+	return r, true
+}
+
+// Set stores r under key with the given ttl.
+func (c *RedisCache) Set(key string, r Response, ttl time.Duration) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	c.client.Set(context.Background(), redisKeyPrefix+key, data, ttl)
+}
+
+// Flush deletes every key under redisKeyPrefix, leaving the rest of the
+// Redis database (which may be shared with other services) untouched.
+func (c *RedisCache) Flush() {
+	ctx := context.Background()
+	iter := c.client.Scan(ctx, 0, redisKeyPrefix+"*", 100).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) >= 100 {
+			c.client.Unlink(ctx, keys...)
+			keys = keys[:0]
+		}
+	}
+	if len(keys) > 0 {
+		c.client.Unlink(ctx, keys...)
+	}
+}
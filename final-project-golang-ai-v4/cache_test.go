@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestCacheKeyDiffersByProvider(t *testing.T) {
+	table := map[string][]string{"col": {"a", "b"}}
+
+	hf := CacheKey("huggingface", table, "how many rows?", nil)
+	oai := CacheKey("openai", table, "how many rows?", nil)
+
+	if hf == oai {
+		t.Fatalf("CacheKey must differ across providers, got identical key %q for both", hf)
+	}
+}
+
+func TestCacheKeyCanonicalizesTableAndQuery(t *testing.T) {
+	a := CacheKey("openai", map[string][]string{
+		"name": {" Alice ", "Bob"},
+		"age":  {"30", "40"},
+	}, "  How Many   rows ", nil)
+
+	b := CacheKey("openai", map[string][]string{
+		"age":  {"30", "40"},
+		"name": {"Alice", "Bob"},
+	}, "how many rows", nil)
+
+	if a != b {
+		t.Fatalf("CacheKey should be stable across column order and whitespace, got %q != %q", a, b)
+	}
+}
+
+func TestCacheKeyDiffersByQuery(t *testing.T) {
+	table := map[string][]string{"col": {"a"}}
+
+	first := CacheKey("openai", table, "how many rows?", nil)
+	second := CacheKey("openai", table, "what is the average?", nil)
+
+	if first == second {
+		t.Fatalf("CacheKey must differ across distinct queries, got identical key %q for both", first)
+	}
+}
+
+func TestCacheKeyDiffersByHistory(t *testing.T) {
+	table := map[string][]string{"col": {"a"}}
+
+	noHistory := CacheKey("openai", table, "and for the previous year?", nil)
+	withHistory := CacheKey("openai", table, "and for the previous year?", []Message{
+		{Role: "user", Content: "how many rows in 2023?"},
+		{Role: "assistant", Content: "42"},
+	})
+
+	if noHistory == withHistory {
+		t.Fatalf("CacheKey must differ when prior conversation history differs, got identical key %q for both", noHistory)
+	}
+}
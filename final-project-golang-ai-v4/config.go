@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMaxHistory is used when the config file doesn't set max_history.
+const defaultMaxHistory = 10
+
+// defaultCacheMaxEntries and defaultCacheTTLSeconds apply when cache is
+// enabled but max_entries/ttl_seconds aren't set.
+const (
+	defaultCacheMaxEntries = 1000
+	defaultCacheTTLSeconds = 3600
+)
+
+// defaultHistoryDBPath is used when history.backend is "sqlite" but
+// history.path isn't set.
+const defaultHistoryDBPath = "history.db"
+
+// CacheConfig controls the optional response cache sitting in front of
+// every provider.
+type CacheConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Backend       string `yaml:"backend"` // "memory" (default) or "redis"
+	MaxEntries    int    `yaml:"max_entries"`
+	TTLSeconds    int    `yaml:"ttl_seconds"`
+	RedisAddr     string `yaml:"redis_addr"`
+	AdminTokenEnv string `yaml:"admin_token_env"` // env var holding the token required to DELETE /cache
+}
+
+// ProviderConfig holds the settings needed to construct a single ModelProvider.
+type ProviderConfig struct {
+	Type       string            `yaml:"type"`     // huggingface, openai, watsonx, local
+	BaseURL    string            `yaml:"base_url"` // overrides the provider's default endpoint
+	Model      string            `yaml:"model"`
+	APIKeyEnv  string            `yaml:"api_key_env"` // name of the env var holding the API key
+	ProjectID  string            `yaml:"project_id"`  // used by watsonx
+	Parameters map[string]string `yaml:"parameters"`  // provider-specific extra parameters
+}
+
+// HistoryConfig controls where conversation history is persisted.
+type HistoryConfig struct {
+	Backend string `yaml:"backend"` // "memory" (default) or "sqlite"
+	Path    string `yaml:"path"`    // SQLite database file; used when backend is "sqlite"
+}
+
+// Config is the top-level application configuration, loaded from a YAML file
+// and overridable via environment variables.
+type Config struct {
+	DefaultProvider string                    `yaml:"default_provider"`
+	Providers       map[string]ProviderConfig `yaml:"providers"`
+	MaxHistory      int                       `yaml:"max_history"` // messages retained per session; defaults to defaultMaxHistory if unset
+	Cache           CacheConfig               `yaml:"cache"`
+	History         HistoryConfig             `yaml:"history"`
+}
+
+// LoadConfig reads the YAML config at path, expanding "${VAR}"/"$VAR"
+// references against the process environment (so e.g. project_id:
+// ${WATSONX_PROJECT_ID} resolves before parsing), and applies the
+// environment variable overrides described on applyEnvOverrides on top of
+// it, before validation, so deployments can tweak a single field (e.g. the
+// model) without editing the file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	cfg.applyEnvOverrides()
+
+	if cfg.DefaultProvider == "" {
+		return nil, fmt.Errorf("config: default_provider must be set")
+	}
+	if _, ok := cfg.Providers[cfg.DefaultProvider]; !ok {
+		return nil, fmt.Errorf("config: default_provider %q has no matching entry under providers", cfg.DefaultProvider)
+	}
+	if cfg.MaxHistory == 0 {
+		cfg.MaxHistory = defaultMaxHistory
+	}
+	if cfg.Cache.Enabled {
+		if cfg.Cache.MaxEntries == 0 {
+			cfg.Cache.MaxEntries = defaultCacheMaxEntries
+		}
+		if cfg.Cache.TTLSeconds == 0 {
+			cfg.Cache.TTLSeconds = defaultCacheTTLSeconds
+		}
+		if cfg.Cache.Backend == "" {
+			cfg.Cache.Backend = "memory"
+		}
+	}
+	if cfg.History.Backend == "" {
+		cfg.History.Backend = "memory"
+	}
+	if cfg.History.Backend == "sqlite" && cfg.History.Path == "" {
+		cfg.History.Path = defaultHistoryDBPath
+	}
+
+	return &cfg, nil
+}
+
+// applyEnvOverrides lets PROVIDERS_<NAME>_BASE_URL and PROVIDERS_<NAME>_MODEL
+// (name upper-cased, non-alphanumerics replaced with "_"), and
+// DEFAULT_PROVIDER, override whatever was loaded from the config file.
+func (c *Config) applyEnvOverrides() {
+	for name, pc := range c.Providers {
+		prefix := "PROVIDERS_" + envKey(name) + "_"
+
+		if v := os.Getenv(prefix + "BASE_URL"); v != "" {
+			pc.BaseURL = v
+		}
+		if v := os.Getenv(prefix + "MODEL"); v != "" {
+			pc.Model = v
+		}
+
+		c.Providers[name] = pc
+	}
+
+	if v := os.Getenv("DEFAULT_PROVIDER"); v != "" {
+		c.DefaultProvider = v
+	}
+}
+
+// AdminToken resolves the token required to flush the cache from its
+// configured env var.
+func (cc CacheConfig) AdminToken() string {
+	if cc.AdminTokenEnv == "" {
+		return ""
+	}
+	return os.Getenv(cc.AdminTokenEnv)
+}
+
+// APIKey resolves the provider's API key from its configured env var.
+func (pc ProviderConfig) APIKey() string {
+	if pc.APIKeyEnv == "" {
+		return ""
+	}
+	return os.Getenv(pc.APIKeyEnv)
+}
+
+func envKey(name string) string {
+	replaced := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return strings.ToUpper(replaced)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigRequiresDefaultProvider(t *testing.T) {
+	path := writeConfig(t, `
+providers:
+  hf:
+    type: huggingface
+`)
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error when default_provider is unset, got nil")
+	}
+}
+
+func TestLoadConfigRequiresDefaultProviderToExist(t *testing.T) {
+	path := writeConfig(t, `
+default_provider: missing
+providers:
+  hf:
+    type: huggingface
+`)
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error when default_provider has no matching entry, got nil")
+	}
+}
+
+func TestLoadConfigAppliesDefaults(t *testing.T) {
+	path := writeConfig(t, `
+default_provider: hf
+providers:
+  hf:
+    type: huggingface
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.MaxHistory != defaultMaxHistory {
+		t.Errorf("MaxHistory = %d, want default %d", cfg.MaxHistory, defaultMaxHistory)
+	}
+}
+
+func TestLoadConfigExpandsEnvVars(t *testing.T) {
+	t.Setenv("TEST_WATSONX_PROJECT_ID", "proj-123")
+
+	path := writeConfig(t, `
+default_provider: wx
+providers:
+  wx:
+    type: watsonx
+    project_id: ${TEST_WATSONX_PROJECT_ID}
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.Providers["wx"].ProjectID; got != "proj-123" {
+		t.Errorf("ProjectID = %q, want \"proj-123\"", got)
+	}
+}
+
+func TestLoadConfigKeepsProviderParameters(t *testing.T) {
+	path := writeConfig(t, `
+default_provider: oai
+providers:
+  oai:
+    type: openai
+    parameters:
+      temperature: "0.2"
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.Providers["oai"].Parameters["temperature"]; got != "0.2" {
+		t.Errorf("Parameters[\"temperature\"] = %q, want \"0.2\"", got)
+	}
+}
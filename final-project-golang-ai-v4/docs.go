@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage loads swagger-ui-dist from a CDN and points it at our own
+// /openapi.yaml, so there's no extra static asset to vendor.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Chatbot API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: '/openapi.yaml', dom_id: '#swagger-ui' });
+    };
+  </script>
+</body>
+</html>`
+
+// registerDocsRoutes serves the hand-written OpenAPI spec and a Swagger UI
+// page that browses it.
+func registerDocsRoutes(r *gin.Engine) {
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.File("openapi.yaml")
+	})
+
+	r.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import "fmt"
+
+// Message is a single turn in a conversation, attached to a provider
+// request so chat-style models can see prior context.
+type Message struct {
+	Role    string `json:"role"` // "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// HistoryStore persists per-session conversation turns. MemoryHistoryStore
+// keeps everything in process memory; SQLiteHistoryStore additionally
+// survives restarts.
+type HistoryStore interface {
+	History(sessionID string) []Message
+	Append(sessionID string, msg Message, maxHistory int)
+	Clear(sessionID string)
+}
+
+// ConversationStore keeps the recent turns for each session, bounded to
+// maxHistory messages per session. It's backed by an in-memory HistoryStore
+// by default, or a SQLiteHistoryStore when cfg.History.Backend is "sqlite".
+type ConversationStore struct {
+	store      HistoryStore
+	maxHistory int
+}
+
+// NewConversationStore creates a store that retains at most maxHistory
+// messages per session, entirely in memory.
+func NewConversationStore(maxHistory int) *ConversationStore {
+	return NewConversationStoreWithBackend(NewMemoryHistoryStore(), maxHistory)
+}
+
+// NewConversationStoreWithBackend creates a store that retains at most
+// maxHistory messages per session, persisted through store.
+func NewConversationStoreWithBackend(store HistoryStore, maxHistory int) *ConversationStore {
+	return &ConversationStore{store: store, maxHistory: maxHistory}
+}
+
+// History returns a copy of the stored messages for sessionID.
+func (s *ConversationStore) History(sessionID string) []Message {
+	return s.store.History(sessionID)
+}
+
+// Append adds a turn to sessionID's history, trimming the oldest messages
+// once maxHistory is exceeded.
+func (s *ConversationStore) Append(sessionID string, msg Message) {
+	s.store.Append(sessionID, msg, s.maxHistory)
+}
+
+// Clear removes all stored history for sessionID.
+func (s *ConversationStore) Clear(sessionID string) {
+	s.store.Clear(sessionID)
+}
+
+// NewConversationStoreFromConfig builds the ConversationStore described by
+// cfg.History, defaulting to an in-memory store when backend is unset or
+// "memory".
+func NewConversationStoreFromConfig(cfg *Config) (*ConversationStore, error) {
+	switch cfg.History.Backend {
+	case "sqlite":
+		store, err := NewSQLiteHistoryStore(cfg.History.Path)
+		if err != nil {
+			return nil, fmt.Errorf("history: %w", err)
+		}
+		return NewConversationStoreWithBackend(store, cfg.MaxHistory), nil
+	case "", "memory":
+		return NewConversationStore(cfg.MaxHistory), nil
+	default:
+		return nil, fmt.Errorf("history: unknown backend %q", cfg.History.Backend)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import "sync"
+
+// MemoryHistoryStore is the default HistoryStore: conversation turns live
+// only in process memory and are lost on restart.
+type MemoryHistoryStore struct {
+	mu        sync.Mutex
+	bySession map[string][]Message
+}
+
+// NewMemoryHistoryStore creates an empty in-memory history store.
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{bySession: make(map[string][]Message)}
+}
+
+// History returns a copy of the stored messages for sessionID.
+func (s *MemoryHistoryStore) History(sessionID string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := s.bySession[sessionID]
+	out := make([]Message, len(history))
+	copy(out, history)
+	return out
+}
+
+// Append adds msg to sessionID's history, trimming the oldest messages once
+// maxHistory is exceeded.
+func (s *MemoryHistoryStore) Append(sessionID string, msg Message, maxHistory int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := append(s.bySession[sessionID], msg)
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	s.bySession[sessionID] = history
+}
+
+// Clear removes all stored history for sessionID.
+func (s *MemoryHistoryStore) Clear(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bySession, sessionID)
+}
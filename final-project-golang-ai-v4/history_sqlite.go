@@ -0,0 +1,92 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteHistoryStore persists conversation turns to a SQLite database so
+// history survives restarts, used instead of MemoryHistoryStore when
+// history.backend is "sqlite". Access is still guarded by a mutex since
+// Append's read-modify-write isn't safe to run concurrently for the same
+// session.
+type SQLiteHistoryStore struct {
+	mu sync.Mutex
+	db *sql.DB
+}
+
+// NewSQLiteHistoryStore opens (creating if needed) the SQLite database at
+// path and ensures its schema exists.
+func NewSQLiteHistoryStore(path string) (*SQLiteHistoryStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening history database: %w", err)
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS conversation_history (
+		session_id TEXT PRIMARY KEY,
+		messages   TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating history schema: %w", err)
+	}
+
+	return &SQLiteHistoryStore{db: db}, nil
+}
+
+// History returns the stored messages for sessionID, or nil if none are
+// recorded.
+func (s *SQLiteHistoryStore) History(sessionID string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(sessionID)
+}
+
+// Append adds msg to sessionID's history, trimming the oldest messages once
+// maxHistory is exceeded.
+func (s *SQLiteHistoryStore) Append(sessionID string, msg Message, maxHistory int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := append(s.load(sessionID), msg)
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	s.save(sessionID, history)
+}
+
+// Clear removes all stored history for sessionID.
+func (s *SQLiteHistoryStore) Clear(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.db.Exec(`DELETE FROM conversation_history WHERE session_id = ?`, sessionID)
+}
+
+func (s *SQLiteHistoryStore) load(sessionID string) []Message {
+	var raw string
+	err := s.db.QueryRow(`SELECT messages FROM conversation_history WHERE session_id = ?`, sessionID).Scan(&raw)
+	if err != nil {
+		return nil
+	}
+
+	var history []Message
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+func (s *SQLiteHistoryStore) save(sessionID string, history []Message) {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+
+	s.db.Exec(`INSERT INTO conversation_history (session_id, messages) VALUES (?, ?)
+		ON CONFLICT(session_id) DO UPDATE SET messages = excluded.messages`, sessionID, string(data))
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestConversationStoreAppendTrimsToMaxHistory(t *testing.T) {
+	store := NewConversationStore(2)
+
+	store.Append("sid", Message{Role: "user", Content: "one"})
+	store.Append("sid", Message{Role: "assistant", Content: "two"})
+	store.Append("sid", Message{Role: "user", Content: "three"})
+
+	history := store.History("sid")
+	if len(history) != 2 {
+		t.Fatalf("History() len = %d, want 2", len(history))
+	}
+	if history[0].Content != "two" || history[1].Content != "three" {
+		t.Fatalf("History() = %+v, want oldest message trimmed", history)
+	}
+}
+
+func TestConversationStoreClear(t *testing.T) {
+	store := NewConversationStore(5)
+	store.Append("sid", Message{Role: "user", Content: "hi"})
+
+	store.Clear("sid")
+
+	if history := store.History("sid"); len(history) != 0 {
+		t.Fatalf("History() after Clear = %+v, want empty", history)
+	}
+}
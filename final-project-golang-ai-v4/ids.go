@@ -0,0 +1,15 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newRandomID returns a random hex-encoded ID built from n random bytes.
+func newRandomID(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
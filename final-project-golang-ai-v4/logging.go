@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type contextKey string
+
+const requestIDContextKey contextKey = "request_id"
+
+// RequestLogger assigns each request a short request ID (propagated via
+// context so a slow upstream call can be correlated with the request that
+// triggered it) and logs method/route/status/latency as structured JSON.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		reqID, err := newRandomID(8)
+		if err != nil {
+			reqID = "unknown"
+		}
+
+		ctx := context.WithValue(c.Request.Context(), requestIDContextKey, reqID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer.Header().Set("X-Request-Id", reqID)
+
+		c.Next()
+
+		log.Info().
+			Str("request_id", reqID).
+			Str("method", c.Request.Method).
+			Str("route", c.FullPath()).
+			Int("status", c.Writer.Status()).
+			Dur("latency", time.Since(start)).
+			Msg("handled request")
+	}
+}
+
+// requestIDFromContext returns the request ID assigned by RequestLogger, if
+// any, so provider calls can tag their own log lines with it.
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
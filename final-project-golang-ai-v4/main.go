@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"encoding/csv"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -11,30 +9,14 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-)
-
-// AIModelConnector struct to store http.Client
-type AIModelConnector struct {
-	Client *http.Client
-}
-
-// Inputs struct to define the format of input for the AI model
-type Inputs struct {
-	Table map[string][]string `json:"table"`
-	Query string              `json:"query"`
-}
 
-// Response struct to define the format of response from the AI model
-type Response struct {
-	Answer      string   `json:"answer"`
-	Coordinates [][]int  `json:"coordinates"`
-	Cells       []string `json:"cells"`
-	Aggregator  string   `json:"aggregator"`
-}
+	"example.com/finalproject/api"
+)
 
 // CsvToSlice function to convert CSV into a map
 func CsvToSlice(data string) (map[string][]string, error) {
@@ -63,57 +45,6 @@ func CsvToSlice(data string) (map[string][]string, error) {
 	return result, nil
 }
 
-// ConnectAIModel function to connect to the AI model and get the response
-func (c *AIModelConnector) ConnectAIModel(payload Inputs, token string) (Response, error) {
-	url := "https://api-inference.huggingface.co/models/google/tapas-base-finetuned-wtq"
-	data, err := json.Marshal(payload) // Convert payload to JSON
-	if err != nil {
-		return Response{}, err
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	if err != nil {
-		return Response{}, err
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	// Retry logic to attempt connecting to the AI model if it fails
-	maxRetries := 10
-	for i := 0; i < maxRetries; i++ {
-		resp, err := c.Client.Do(req)
-		if err != nil {
-			return Response{}, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == http.StatusOK {
-			var aiResponse Response
-			if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
-				return Response{}, err
-			}
-			return aiResponse, nil
-		}
-
-		if resp.StatusCode == http.StatusServiceUnavailable {
-			var result map[string]interface{}
-			body, _ := ioutil.ReadAll(resp.Body)
-			if err := json.Unmarshal(body, &result); err == nil {
-				if estimatedTime, ok := result["estimated_time"].(float64); ok {
-					log.Printf("Model is currently loading, retrying in %.1f seconds...\n", estimatedTime)
-					time.Sleep(time.Duration(estimatedTime) * time.Second)
-					continue
-				}
-			}
-		}
-
-		body, _ := ioutil.ReadAll(resp.Body)
-		return Response{}, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
-	}
-
-	return Response{}, fmt.Errorf("max retries reached, failed to connect to AI model")
-}
-
 func main() {
 	// Load environment variables from .env file
 	err := godotenv.Load()
@@ -121,10 +52,10 @@ func main() {
 		log.Fatalf("Error loading .env file: %v\n", err)
 	}
 
-	// Get Huggingface API token from environment variables
-	token := os.Getenv("HUGGINGFACE_TOKEN")
-	if token == "" {
-		log.Fatalf("HUGGINGFACE_TOKEN not found in .env file")
+	// Load provider configuration
+	cfg, err := LoadConfig("config.yaml")
+	if err != nil {
+		log.Fatalf("Error loading config file: %v\n", err)
 	}
 
 	// Path to CSV file
@@ -142,12 +73,39 @@ func main() {
 		log.Fatalf("Error parsing CSV file: %v\n", err)
 	}
 
-	// Create AI model connector
+	// Build the provider registry from config
 	client := &http.Client{}
-	connector := &AIModelConnector{Client: client}
+	providers, err := NewProviderRegistry(cfg, client)
+	if err != nil {
+		log.Fatalf("Error initializing providers: %v\n", err)
+	}
+
+	// Conversation history, keyed by session ID
+	conversations, err := NewConversationStoreFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Error initializing conversation history: %v\n", err)
+	}
+
+	// Uploaded tables, keyed by generated table ID
+	tables := NewTableRegistry()
 
 	// Set up Gin router
 	r := gin.Default()
+	r.MaxMultipartMemory = 8 << 20 // 8 MiB
+
+	// Structured request logging and Prometheus instrumentation
+	r.Use(RequestLogger())
+	r.Use(PrometheusMiddleware())
+
+	// Session middleware, used to assign each visitor a stable session ID.
+	// SESSION_SECRET signs the cookie, so an unset value would make session
+	// IDs trivially forgeable; fail fast instead of starting up insecure.
+	sessionSecret := os.Getenv("SESSION_SECRET")
+	if sessionSecret == "" {
+		log.Fatal("SESSION_SECRET environment variable must be set")
+	}
+	store := cookie.NewStore([]byte(sessionSecret))
+	r.Use(sessions.Sessions("chatbot_session", store))
 
 	// Serve static files
 	r.Static("/static", "./static")
@@ -170,35 +128,117 @@ func main() {
 		c.HTML(http.StatusOK, "contact.html", gin.H{})
 	})
 
-	// Handle chatbot query
+	// Handle chatbot query against the default provider
 	r.POST("/query", func(c *gin.Context) {
-		query := c.PostForm("query")
-		if query == "" {
-			c.HTML(http.StatusBadRequest, "index.html", gin.H{"error": "Query cannot be empty"})
-			return
-		}
-
-		payload := Inputs{
-			Table: table,
-			Query: query,
-		}
+		handleQuery(c, providers.Default(), table, tables, conversations)
+	})
 
-		response, err := connector.ConnectAIModel(payload, token)
-		if err != nil {
-			c.HTML(http.StatusInternalServerError, "index.html", gin.H{"error": fmt.Sprintf("Error connecting to AI model: %v", err)})
+	// Handle chatbot query against a specific named provider
+	r.POST("/providers/:name/query", func(c *gin.Context) {
+		name := c.Param("name")
+		provider, ok := providers.Get(name)
+		if !ok {
+			c.HTML(http.StatusNotFound, "index.html", gin.H{"error": fmt.Sprintf("unknown provider %q", name)})
 			return
 		}
+		handleQuery(c, provider, table, tables, conversations)
+	})
 
-		// Display response
-		c.HTML(http.StatusOK, "index.html", gin.H{
-			"query":       query,
-			"answer":      response.Answer,
-			"coordinates": response.Coordinates,
-			"cells":       response.Cells,
-			"aggregator":  response.Aggregator,
-		})
+	// Handle chatbot query as a Server-Sent Events stream
+	r.POST("/query/stream", func(c *gin.Context) {
+		handleQueryStream(c, providers.Default(), table, tables, conversations)
 	})
 
+	// Structured JSON API, same logic as /query but always JSON in/out
+	r.POST("/api/v1/query", func(c *gin.Context) {
+		handleQueryJSON(c, providers.Default(), table, tables, conversations)
+	})
+
+	// Hand-written OpenAPI spec and a Swagger UI to browse it
+	registerDocsRoutes(r)
+
+	// Inspect or reset a session's conversation history
+	registerSessionRoutes(r, conversations)
+
+	// Upload and manage CSV tables
+	registerTableRoutes(r, tables)
+
+	// Prometheus scrape endpoint
+	registerMetricsRoute(r)
+
+	// Admin-token-protected cache flush
+	registerCacheRoutes(r, providers, cfg.Cache)
+
 	// Start the server
 	r.Run(":8080")
 }
+
+// queryRequestFromForm builds an api.QueryRequest from the POSTed form
+// fields shared by the HTML and JSON handlers.
+func queryRequestFromForm(c *gin.Context) api.QueryRequest {
+	return api.QueryRequest{
+		Query:       c.PostForm("query"),
+		TableID:     c.PostForm("table_id"),
+		CacheBypass: c.PostForm("cache_bypass") == "true",
+	}
+}
+
+// handleQuery reads the query form, resolves it through the same
+// queryResolver the JSON API uses, and renders the chatbot page (or a JSON
+// body, for clients that send "Accept: application/json"). It's a thin
+// adapter over the logic api.HandleQuery exposes at /api/v1/query.
+func handleQuery(c *gin.Context, provider ModelProvider, table map[string][]string, tables *TableRegistry, conversations *ConversationStore) {
+	wantsJSON := c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+	resolver := &queryResolver{provider: provider, table: table, tables: tables, conversations: conversations}
+
+	sid, err := sessionID(c)
+	if err != nil {
+		respondQueryError(c, wantsJSON, http.StatusInternalServerError, fmt.Sprintf("Error establishing session: %v", err))
+		return
+	}
+
+	resp, err := resolver.Resolve(c.Request.Context(), sid, queryRequestFromForm(c))
+	if err != nil {
+		respondQueryError(c, wantsJSON, queryErrorStatus(err), err.Error())
+		return
+	}
+
+	if wantsJSON {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	c.HTML(http.StatusOK, "index.html", gin.H{
+		"query":       resp.Query,
+		"answer":      resp.Answer,
+		"coordinates": resp.Coordinates,
+		"cells":       resp.Cells,
+		"aggregator":  resp.Aggregator,
+	})
+}
+
+// handleQueryJSON is the dedicated JSON API handler behind /api/v1/query,
+// delegating to the api package's handler with a queryResolver bound to
+// this request's provider/table/session state.
+func handleQueryJSON(c *gin.Context, provider ModelProvider, table map[string][]string, tables *TableRegistry, conversations *ConversationStore) {
+	resolver := &queryResolver{provider: provider, table: table, tables: tables, conversations: conversations}
+	api.HandleQuery(c, resolver, sessionID, queryErrorStatus)
+}
+
+func respondQueryError(c *gin.Context, wantsJSON bool, status int, message string) {
+	if wantsJSON {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	c.HTML(status, "index.html", gin.H{"error": message})
+}
+
+// queryErrorStatus maps a queryResolver error to an HTTP status: a blank
+// query or an unknown table_id is the caller's fault, anything else is an
+// upstream/provider failure.
+func queryErrorStatus(err error) int {
+	if errors.Is(err, api.ErrEmptyQuery) || errors.Is(err, errUnknownTable) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	queriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chatbot_queries_total",
+		Help: "Total number of queries handled, labeled by provider.",
+	}, []string{"provider"})
+
+	upstreamLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chatbot_upstream_latency_seconds",
+		Help:    "Latency of calls to the upstream model provider.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	upstreamRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chatbot_upstream_retries_total",
+		Help: "Number of retry attempts made against an upstream provider.",
+	}, []string{"provider"})
+
+	modelLoadingTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chatbot_model_loading_total",
+		Help: "Number of HTTP 503 'model is loading' responses seen from a provider.",
+	}, []string{"provider"})
+
+	responseSizeBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chatbot_response_size_bytes",
+		Help:    "Size in bytes of the answer text returned by a provider.",
+		Buckets: prometheus.ExponentialBuckets(16, 2, 10),
+	}, []string{"provider"})
+
+	httpRequestDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chatbot_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+// PrometheusMiddleware records per-route latency and status codes for every
+// request handled by the router.
+func PrometheusMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequestDurationSeconds.
+			WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// registerMetricsRoute exposes the Prometheus scrape endpoint.
+func registerMetricsRoute(r *gin.Engine) {
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Inputs struct to define the format of input for the AI model
+type Inputs struct {
+	Table       map[string][]string `json:"table"`
+	Query       string              `json:"query"`
+	History     []Message           `json:"history,omitempty"`
+	CacheBypass bool                `json:"-"`
+}
+
+// Response struct to define the format of response from the AI model
+type Response struct {
+	Answer      string   `json:"answer"`
+	Coordinates [][]int  `json:"coordinates"`
+	Cells       []string `json:"cells"`
+	Aggregator  string   `json:"aggregator"`
+}
+
+// RenderTableQueryPrompt flattens a table/query payload into a plain-text
+// prompt for chat- and completion-style providers that don't understand the
+// TAPAS table/query wire format natively. Columns are sorted, matching
+// CacheKey's canonicalization, so the same table renders identically on
+// every call instead of varying with Go's randomized map iteration order.
+func RenderTableQueryPrompt(payload Inputs) string {
+	columns := make([]string, 0, len(payload.Table))
+	for col := range payload.Table {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	var b strings.Builder
+
+	b.WriteString("Table:\n")
+	for _, col := range columns {
+		fmt.Fprintf(&b, "%s: %s\n", col, strings.Join(payload.Table[col], ", "))
+	}
+
+	b.WriteString("\nQuestion: ")
+	b.WriteString(payload.Query)
+
+	return b.String()
+}
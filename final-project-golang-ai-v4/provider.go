@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ModelProvider is implemented by every backend the chatbot can route
+// queries to. Concrete implementations live in provider_<name>.go.
+type ModelProvider interface {
+	Invoke(ctx context.Context, payload Inputs) (Response, error)
+}
+
+// NewProvider builds the ModelProvider described by pc, reusing client for
+// outbound HTTP requests.
+func NewProvider(pc ProviderConfig, client *http.Client) (ModelProvider, error) {
+	switch pc.Type {
+	case "huggingface":
+		return &HuggingFaceProvider{Client: client, Config: pc}, nil
+	case "openai":
+		return &OpenAIProvider{Client: client, Config: pc}, nil
+	case "watsonx":
+		return &WatsonxProvider{Client: client, Config: pc}, nil
+	case "local":
+		return &LocalProvider{Client: client, Config: pc}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", pc.Type)
+	}
+}
+
+// ProviderRegistry resolves providers by the name they were configured
+// under, so handlers can target a specific backend by name.
+type ProviderRegistry struct {
+	byName      map[string]ModelProvider
+	defaultName string
+	cache       ResponseCache // nil unless cfg.Cache.Enabled
+}
+
+// NewProviderRegistry constructs every provider declared in cfg, wrapping
+// each with, if cfg.Cache.Enabled, a shared response cache, then
+// instrumentation. Instrumentation sits outermost so cache hits still pass
+// through the metrics wrapper instead of going unobserved.
+func NewProviderRegistry(cfg *Config, client *http.Client) (*ProviderRegistry, error) {
+	reg := &ProviderRegistry{
+		byName:      make(map[string]ModelProvider, len(cfg.Providers)),
+		defaultName: cfg.DefaultProvider,
+	}
+
+	var cache ResponseCache
+	if cfg.Cache.Enabled {
+		switch cfg.Cache.Backend {
+		case "redis":
+			cache = NewRedisCache(cfg.Cache.RedisAddr)
+		default:
+			cache = NewMemoryCache(cfg.Cache.MaxEntries)
+		}
+	}
+
+	for name, pc := range cfg.Providers {
+		provider, err := NewProvider(pc, client)
+		if err != nil {
+			return nil, fmt.Errorf("provider %q: %w", name, err)
+		}
+
+		if cache != nil {
+			provider = CacheProvider(name, provider, cache, time.Duration(cfg.Cache.TTLSeconds)*time.Second)
+		}
+		provider = InstrumentProvider(name, provider)
+
+		reg.byName[name] = provider
+	}
+
+	reg.cache = cache
+	return reg, nil
+}
+
+// Get returns the provider registered under name.
+func (r *ProviderRegistry) Get(name string) (ModelProvider, bool) {
+	p, ok := r.byName[name]
+	return p, ok
+}
+
+// Default returns the provider configured as default_provider.
+func (r *ProviderRegistry) Default() ModelProvider {
+	return r.byName[r.defaultName]
+}
+
+// Cache returns the shared response cache, or nil if caching is disabled.
+func (r *ProviderRegistry) Cache() ResponseCache {
+	return r.cache
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// cachingProvider wraps a ModelProvider with a ResponseCache, keyed by
+// CacheKey(name, table, query, history) so distinct providers never share
+// cache entries and a follow-up with different prior context never collides
+// with another session's cached answer. Streaming calls are passed straight
+// through since partial chunks aren't memoized.
+type cachingProvider struct {
+	name  string
+	inner ModelProvider
+	cache ResponseCache
+	ttl   time.Duration
+}
+
+// CacheProvider wraps provider so repeated (table, query) pairs asked of the
+// provider registered under name are served from cache instead of hitting
+// the upstream model.
+func CacheProvider(name string, provider ModelProvider, cache ResponseCache, ttl time.Duration) ModelProvider {
+	base := &cachingProvider{name: name, inner: provider, cache: cache, ttl: ttl}
+
+	if sp, ok := provider.(StreamingProvider); ok {
+		return &cachingStreamingProvider{cachingProvider: base, streamInner: sp}
+	}
+	return base
+}
+
+func (p *cachingProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	if payload.CacheBypass {
+		return p.inner.Invoke(ctx, payload)
+	}
+
+	key := CacheKey(p.name, payload.Table, payload.Query, payload.History)
+	if cached, ok := p.cache.Get(key); ok {
+		return cached, nil
+	}
+
+	response, err := p.inner.Invoke(ctx, payload)
+	if err != nil {
+		return Response{}, err
+	}
+
+	p.cache.Set(key, response, p.ttl)
+	return response, nil
+}
+
+type cachingStreamingProvider struct {
+	*cachingProvider
+	streamInner StreamingProvider
+}
+
+func (p *cachingStreamingProvider) InvokeStream(ctx context.Context, payload Inputs) (<-chan ResponseChunk, error) {
+	return p.streamInner.InvokeStream(ctx, payload)
+}
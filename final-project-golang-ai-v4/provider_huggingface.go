@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HuggingFaceProvider talks to the Hugging Face Inference API. This is the
+// original TAPAS table-QA integration, now behind the ModelProvider interface.
+type HuggingFaceProvider struct {
+	Client *http.Client
+	Config ProviderConfig
+}
+
+// tapasQueryWithHistory prefixes query with the most recent prior turn so a
+// follow-up like "and for the previous year?" carries enough context for
+// TAPAS to have a chance of resolving it, since the wire format has no
+// separate field for conversation history.
+func tapasQueryWithHistory(payload Inputs) string {
+	if len(payload.History) == 0 {
+		return payload.Query
+	}
+
+	prev := payload.History[len(payload.History)-1]
+	var b strings.Builder
+	fmt.Fprintf(&b, "Previous %s: %s. ", prev.Role, prev.Content)
+	b.WriteString(payload.Query)
+	return b.String()
+}
+
+// Invoke posts payload to the configured Hugging Face model endpoint,
+// retrying while the model is still loading (HTTP 503 + estimated_time).
+func (p *HuggingFaceProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	// TAPAS has no notion of conversation history as a separate field, so the
+	// most recent turn is folded into the query text itself.
+	wirePayload := Inputs{Table: payload.Table, Query: tapasQueryWithHistory(payload)}
+	data, err := json.Marshal(wirePayload)
+	if err != nil {
+		return Response{}, err
+	}
+
+	// Retry logic to attempt connecting to the AI model if it fails
+	maxRetries := 10
+	for i := 0; i < maxRetries; i++ {
+		if i > 0 {
+			upstreamRetriesTotal.WithLabelValues("huggingface").Inc()
+		}
+
+		// A fresh request per attempt, since the previous attempt consumed
+		// the body.
+		req, err := http.NewRequestWithContext(ctx, "POST", p.Config.BaseURL, bytes.NewBuffer(data))
+		if err != nil {
+			return Response{}, err
+		}
+		req.Header.Set("Authorization", "Bearer "+p.Config.APIKey())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.Client.Do(req)
+		if err != nil {
+			return Response{}, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			var aiResponse Response
+			if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
+				return Response{}, err
+			}
+			return aiResponse, nil
+		}
+
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			modelLoadingTotal.WithLabelValues("huggingface").Inc()
+
+			var result map[string]interface{}
+			body, _ := ioutil.ReadAll(resp.Body)
+			if err := json.Unmarshal(body, &result); err == nil {
+				if estimatedTime, ok := result["estimated_time"].(float64); ok {
+					log.Printf("[%s] Model is currently loading, retrying in %.1f seconds...\n", requestIDFromContext(ctx), estimatedTime)
+					time.Sleep(time.Duration(estimatedTime) * time.Second)
+					continue
+				}
+			}
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
+	}
+
+	return Response{}, fmt.Errorf("max retries reached, failed to connect to AI model")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// instrumentedProvider wraps a ModelProvider with Prometheus metrics for
+// query counts, upstream latency, and response size.
+type instrumentedProvider struct {
+	name  string
+	inner ModelProvider
+}
+
+// instrumentedStreamingProvider additionally implements StreamingProvider
+// when the wrapped provider does, so streamProvider's type assertion keeps
+// working through the wrapper.
+type instrumentedStreamingProvider struct {
+	*instrumentedProvider
+	streamInner StreamingProvider
+}
+
+// InstrumentProvider wraps provider so every call is recorded under name.
+func InstrumentProvider(name string, provider ModelProvider) ModelProvider {
+	base := &instrumentedProvider{name: name, inner: provider}
+
+	if sp, ok := provider.(StreamingProvider); ok {
+		return &instrumentedStreamingProvider{instrumentedProvider: base, streamInner: sp}
+	}
+	return base
+}
+
+func (p *instrumentedProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	queriesTotal.WithLabelValues(p.name).Inc()
+
+	start := time.Now()
+	response, err := p.inner.Invoke(ctx, payload)
+	upstreamLatencySeconds.WithLabelValues(p.name).Observe(time.Since(start).Seconds())
+
+	if err == nil {
+		responseSizeBytes.WithLabelValues(p.name).Observe(float64(len(response.Answer)))
+	}
+
+	return response, err
+}
+
+func (p *instrumentedStreamingProvider) InvokeStream(ctx context.Context, payload Inputs) (<-chan ResponseChunk, error) {
+	queriesTotal.WithLabelValues(p.name).Inc()
+
+	start := time.Now()
+	chunks, err := p.streamInner.InvokeStream(ctx, payload)
+	if err != nil {
+		upstreamLatencySeconds.WithLabelValues(p.name).Observe(time.Since(start).Seconds())
+		return nil, err
+	}
+
+	out := make(chan ResponseChunk)
+	go func() {
+		defer close(out)
+		var size int
+		for chunk := range chunks {
+			size += len(chunk.Content)
+			if !sendChunk(ctx, out, chunk) {
+				return
+			}
+		}
+		upstreamLatencySeconds.WithLabelValues(p.name).Observe(time.Since(start).Seconds())
+		responseSizeBytes.WithLabelValues(p.name).Observe(float64(size))
+	}()
+
+	return out, nil
+}
+
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+// LocalProvider talks to a self-hosted OpenAI-compatible chat endpoint, such
+// as LocalAI or Ollama's "/v1/chat/completions" route. The wire format is
+// identical to OpenAIProvider, so it's implemented as a thin wrapper around
+// the same request/response shapes.
+type LocalProvider struct {
+	Client *http.Client
+	Config ProviderConfig
+}
+
+// Invoke delegates to the OpenAI chat/completions wire format, pointed at
+// the configured local base URL.
+func (p *LocalProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	return p.delegate().Invoke(ctx, payload)
+}
+
+// InvokeStream delegates to the OpenAI SSE streaming wire format.
+func (p *LocalProvider) InvokeStream(ctx context.Context, payload Inputs) (<-chan ResponseChunk, error) {
+	return p.delegate().InvokeStream(ctx, payload)
+}
+
+func (p *LocalProvider) delegate() *OpenAIProvider {
+	return &OpenAIProvider{Client: p.Client, Config: p.Config}
+}
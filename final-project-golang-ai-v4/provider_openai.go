@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// OpenAIProvider talks to the OpenAI chat/completions API (or any
+// OpenAI-compatible endpoint). Since chat models don't understand the TAPAS
+// table/query payload directly, the table is serialized into the prompt.
+type OpenAIProvider struct {
+	Client *http.Client
+	Config ProviderConfig
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model      string              `json:"model"`
+	Messages   []openAIChatMessage `json:"messages"`
+	Stream     bool                `json:"stream,omitempty"`
+	Parameters map[string]string   `json:"-"`
+}
+
+// MarshalJSON flattens Parameters (e.g. "temperature", "max_tokens") into the
+// top-level request body alongside model/messages/stream, matching the
+// OpenAI chat/completions wire format.
+func (r openAIChatRequest) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"model":    r.Model,
+		"messages": r.Messages,
+	}
+	if r.Stream {
+		fields["stream"] = r.Stream
+	}
+	for k, v := range r.Parameters {
+		// Numbers and booleans (e.g. "0.2", "256", "true") are sent as JSON
+		// values; anything else stays a string.
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(v), &parsed); err == nil {
+			fields[k] = parsed
+		} else {
+			fields[k] = v
+		}
+	}
+	return json.Marshal(fields)
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// openAIChatMessages builds the system/history/user message list shared by
+// the blocking and streaming request bodies.
+func openAIChatMessages(payload Inputs) []openAIChatMessage {
+	messages := []openAIChatMessage{
+		{Role: "system", Content: "You answer questions about a data table. Reply with the answer only."},
+	}
+	for _, turn := range payload.History {
+		messages = append(messages, openAIChatMessage{Role: turn.Role, Content: turn.Content})
+	}
+	messages = append(messages, openAIChatMessage{Role: "user", Content: RenderTableQueryPrompt(payload)})
+	return messages
+}
+
+// Invoke renders payload as a chat prompt and posts it to the configured
+// OpenAI-compatible endpoint.
+func (p *OpenAIProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	reqBody := openAIChatRequest{
+		Model:      p.Config.Model,
+		Messages:   openAIChatMessages(payload),
+		Parameters: p.Config.Parameters,
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return Response{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.Config.BaseURL, bytes.NewBuffer(data))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.Config.APIKey())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Response{}, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
+	}
+
+	var chatResp openAIChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return Response{}, err
+	}
+	if len(chatResp.Choices) == 0 {
+		return Response{}, fmt.Errorf("openai: empty choices in response")
+	}
+
+	return Response{Answer: chatResp.Choices[0].Message.Content}, nil
+}
+
+type openAIChatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// InvokeStream posts payload with stream=true and forwards each SSE
+// "data: {...}" line's delta.content over the returned channel, closing it
+// once the upstream sends "data: [DONE]" or the body is exhausted.
+func (p *OpenAIProvider) InvokeStream(ctx context.Context, payload Inputs) (<-chan ResponseChunk, error) {
+	reqBody := openAIChatRequest{
+		Model:      p.Config.Model,
+		Messages:   openAIChatMessages(payload),
+		Stream:     true,
+		Parameters: p.Config.Parameters,
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.Config.BaseURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.Config.APIKey())
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
+	}
+
+	ch := make(chan ResponseChunk)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			data := strings.TrimPrefix(line, "data: ")
+			if data == "[DONE]" {
+				sendChunk(ctx, ch, ResponseChunk{Done: true})
+				return
+			}
+
+			var chunk openAIChatStreamChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				sendChunk(ctx, ch, ResponseChunk{Err: err, Done: true})
+				return
+			}
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			if !sendChunk(ctx, ch, ResponseChunk{Content: chunk.Choices[0].Delta.Content}) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			sendChunk(ctx, ch, ResponseChunk{Err: err, Done: true})
+			return
+		}
+		sendChunk(ctx, ch, ResponseChunk{Done: true})
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// watsonxIAMTokenURL is IBM Cloud's IAM token endpoint. watsonx.ai doesn't
+// accept the configured API key directly as a bearer token; it must first
+// be exchanged here for a short-lived access token.
+const watsonxIAMTokenURL = "https://iam.cloud.ibm.com/identity/token"
+
+// watsonxIAMExpiryMargin is subtracted from the token's reported expiry so
+// Invoke never races a token that expires mid-request.
+const watsonxIAMExpiryMargin = 60 * time.Second
+
+// WatsonxProvider talks to the IBM watsonx.ai text generation API,
+// exchanging the configured API key for a short-lived IAM access token
+// before each call (cached until shortly before it expires).
+type WatsonxProvider struct {
+	Client *http.Client
+	Config ProviderConfig
+
+	mu        sync.Mutex
+	iamToken  string
+	iamExpiry time.Time
+}
+
+type watsonxIAMTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// iamAccessToken returns a cached IAM access token, exchanging the
+// configured API key for a new one via watsonxIAMTokenURL once it's close
+// to expiring.
+func (p *WatsonxProvider) iamAccessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.iamToken != "" && time.Now().Before(p.iamExpiry) {
+		return p.iamToken, nil
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     {p.Config.APIKey()},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", watsonxIAMTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("watsonx: exchanging API key for IAM token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("watsonx: IAM token exchange failed, status: %s, response: %s", resp.Status, string(body))
+	}
+
+	var tokenResp watsonxIAMTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("watsonx: parsing IAM token response: %w", err)
+	}
+
+	p.iamToken = tokenResp.AccessToken
+	p.iamExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - watsonxIAMExpiryMargin)
+	return p.iamToken, nil
+}
+
+type watsonxGenerateRequest struct {
+	ModelID    string            `json:"model_id"`
+	Input      string            `json:"input"`
+	ProjectID  string            `json:"project_id"`
+	Parameters map[string]string `json:"parameters,omitempty"` // decoding_method, max_new_tokens, etc.
+}
+
+type watsonxGenerateResponse struct {
+	Results []struct {
+		GeneratedText string `json:"generated_text"`
+	} `json:"results"`
+}
+
+// Invoke renders payload as a text prompt and posts it to watsonx's
+// text/generation endpoint.
+func (p *WatsonxProvider) Invoke(ctx context.Context, payload Inputs) (Response, error) {
+	reqBody := watsonxGenerateRequest{
+		ModelID:    p.Config.Model,
+		Input:      RenderTableQueryPrompt(payload),
+		ProjectID:  p.Config.ProjectID,
+		Parameters: p.Config.Parameters,
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return Response{}, err
+	}
+
+	token, err := p.iamAccessToken(ctx)
+	if err != nil {
+		return Response{}, err
+	}
+
+	endpoint := p.Config.BaseURL + "/ml/v1/text/generation?version=2023-05-29"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(data))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Response{}, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
+	}
+
+	var genResp watsonxGenerateResponse
+	if err := json.Unmarshal(body, &genResp); err != nil {
+		return Response{}, err
+	}
+	if len(genResp.Results) == 0 {
+		return Response{}, fmt.Errorf("watsonx: empty results in response")
+	}
+
+	return Response{Answer: genResp.Results[0].GeneratedText}, nil
+}
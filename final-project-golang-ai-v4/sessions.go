@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+const sessionIDKey = "session_id"
+
+// sessionID returns the current request's session ID, creating and
+// persisting one in the session cookie on first use.
+func sessionID(c *gin.Context) (string, error) {
+	session := sessions.Default(c)
+
+	id, _ := session.Get(sessionIDKey).(string)
+	if id != "" {
+		return id, nil
+	}
+
+	id, err := newRandomID(16)
+	if err != nil {
+		return "", err
+	}
+
+	session.Set(sessionIDKey, id)
+	if err := session.Save(); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// registerSessionRoutes wires the history inspection/reset endpoints backed
+// by conv. Both are self-service only: the :id path parameter must match
+// the caller's own session-cookie ID, so one session can't read or wipe
+// another session's conversation history.
+func registerSessionRoutes(r *gin.Engine, conv *ConversationStore) {
+	r.GET("/sessions/:id/history", func(c *gin.Context) {
+		id, err := sessionID(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if c.Param("id") != id {
+			c.JSON(http.StatusForbidden, gin.H{"error": "cannot access another session's history"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"history": conv.History(id)})
+	})
+
+	r.DELETE("/sessions/:id", func(c *gin.Context) {
+		id, err := sessionID(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if c.Param("id") != id {
+			c.JSON(http.StatusForbidden, gin.H{"error": "cannot clear another session's history"})
+			return
+		}
+		conv.Clear(id)
+		c.Status(http.StatusNoContent)
+	})
+}
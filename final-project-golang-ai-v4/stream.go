@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseChunk is a single piece of a streamed model response.
+type ResponseChunk struct {
+	Content string `json:"content"`
+	Done    bool   `json:"done"`
+	Err     error  `json:"-"`
+}
+
+// StreamingProvider is implemented by providers that can stream partial
+// output as it's generated. Providers that don't implement it fall back to
+// a single final chunk built from Invoke.
+type StreamingProvider interface {
+	InvokeStream(ctx context.Context, payload Inputs) (<-chan ResponseChunk, error)
+}
+
+// sendChunk sends chunk on ch, unless ctx is cancelled first. Streaming
+// providers must use this (rather than a plain send) for every chunk: once
+// the client disconnects, gin.Context.Stream stops reading chunks but only
+// learns about the disconnect between step calls, so a bare send would
+// block forever and leak the producer goroutine along with the upstream
+// connection it holds open.
+func sendChunk(ctx context.Context, ch chan<- ResponseChunk, chunk ResponseChunk) bool {
+	select {
+	case ch <- chunk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// streamProvider returns a channel of chunks for provider, using its native
+// streaming support when available and otherwise emitting one final chunk
+// from a blocking Invoke call.
+func streamProvider(ctx context.Context, provider ModelProvider, payload Inputs) (<-chan ResponseChunk, error) {
+	if sp, ok := provider.(StreamingProvider); ok {
+		return sp.InvokeStream(ctx, payload)
+	}
+
+	ch := make(chan ResponseChunk, 1)
+	go func() {
+		defer close(ch)
+		response, err := provider.Invoke(ctx, payload)
+		if err != nil {
+			ch <- ResponseChunk{Err: err, Done: true}
+			return
+		}
+		ch <- ResponseChunk{Content: response.Answer, Done: true}
+	}()
+	return ch, nil
+}
+
+// handleQueryStream streams the answer to query as Server-Sent Events,
+// pumping chunks from the provider (native streaming where supported, a
+// single final chunk otherwise) to the browser as they arrive.
+func handleQueryStream(c *gin.Context, provider ModelProvider, table map[string][]string, tables *TableRegistry, conversations *ConversationStore) {
+	query := c.PostForm("query")
+	if query == "" {
+		c.String(http.StatusBadRequest, "query cannot be empty")
+		return
+	}
+
+	resolvedTable, err := resolveTable(tables, c.PostForm("table_id"), table)
+	if err != nil {
+		c.String(http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	sid, err := sessionID(c)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "error establishing session: %v", err)
+		return
+	}
+
+	payload := Inputs{
+		Table:       resolvedTable,
+		Query:       query,
+		History:     conversations.History(sid),
+		CacheBypass: c.PostForm("cache_bypass") == "true",
+	}
+
+	chunks, err := streamProvider(c.Request.Context(), provider, payload)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "error connecting to AI model: %v", err)
+		return
+	}
+
+	var answer strings.Builder
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	c.Stream(func(w io.Writer) bool {
+		chunk, ok := <-chunks
+		if !ok {
+			return false
+		}
+		if chunk.Err != nil {
+			c.SSEvent("error", chunk.Err.Error())
+			return false
+		}
+
+		answer.WriteString(chunk.Content)
+		c.SSEvent("message", chunk.Content)
+
+		if chunk.Done {
+			conversations.Append(sid, Message{Role: "user", Content: query})
+			conversations.Append(sid, Message{Role: "assistant", Content: answer.String()})
+			return false
+		}
+		return true
+	})
+}
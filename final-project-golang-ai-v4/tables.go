@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	maxTableRows = 10000
+	maxTableCols = 200
+
+	// maxUploadBytes bounds the raw CSV upload body. It's enforced with
+	// http.MaxBytesReader before the file is read into memory, so an
+	// oversized upload is rejected instead of exhausting server memory.
+	maxUploadBytes = 16 << 20 // 16 MiB
+)
+
+// ColumnSchema describes one inferred column of an uploaded table.
+type ColumnSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // "integer", "float", "boolean", or "string"
+}
+
+// Table is a parsed CSV upload, registered under a generated ID so it can
+// be targeted by later queries.
+type Table struct {
+	ID      string              `json:"id"`
+	Data    map[string][]string `json:"-"`
+	Columns []ColumnSchema      `json:"columns"`
+	Rows    int                 `json:"rows"`
+}
+
+// TableRegistry stores uploaded tables in memory, keyed by generated ID.
+type TableRegistry struct {
+	mu     sync.RWMutex
+	tables map[string]*Table
+}
+
+// NewTableRegistry creates an empty registry.
+func NewTableRegistry() *TableRegistry {
+	return &TableRegistry{tables: make(map[string]*Table)}
+}
+
+// Register validates data and stores it under a newly generated table ID.
+func (r *TableRegistry) Register(data map[string][]string) (*Table, error) {
+	if err := validateTableSize(data); err != nil {
+		return nil, err
+	}
+
+	id, err := newRandomID(8)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{
+		ID:      id,
+		Data:    data,
+		Columns: inferSchema(data),
+		Rows:    tableRowCount(data),
+	}
+
+	r.mu.Lock()
+	r.tables[id] = table
+	r.mu.Unlock()
+
+	return table, nil
+}
+
+// Get returns the table registered under id.
+func (r *TableRegistry) Get(id string) (*Table, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tables[id]
+	return t, ok
+}
+
+// List returns every registered table.
+func (r *TableRegistry) List() []*Table {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*Table, 0, len(r.tables))
+	for _, t := range r.tables {
+		out = append(out, t)
+	}
+	return out
+}
+
+// Delete removes the table registered under id.
+func (r *TableRegistry) Delete(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tables, id)
+}
+
+func validateTableSize(data map[string][]string) error {
+	if len(data) > maxTableCols {
+		return fmt.Errorf("table has %d columns, exceeds limit of %d", len(data), maxTableCols)
+	}
+	if rows := tableRowCount(data); rows > maxTableRows {
+		return fmt.Errorf("table has %d rows, exceeds limit of %d", rows, maxTableRows)
+	}
+	return nil
+}
+
+func tableRowCount(data map[string][]string) int {
+	for _, col := range data {
+		return len(col)
+	}
+	return 0
+}
+
+// inferSchema guesses a simple type per column by checking whether every
+// value parses as an integer, a float, or a boolean, falling back to string.
+func inferSchema(data map[string][]string) []ColumnSchema {
+	columns := make([]ColumnSchema, 0, len(data))
+	for name, values := range data {
+		columns = append(columns, ColumnSchema{Name: name, Type: inferColumnType(values)})
+	}
+	return columns
+}
+
+func inferColumnType(values []string) string {
+	isInt, isFloat, isBool := true, true, true
+	sawValue := false
+
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		sawValue = true
+		if isInt {
+			if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+				isInt = false
+			}
+		}
+		if isFloat {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				isFloat = false
+			}
+		}
+		if isBool {
+			if _, err := strconv.ParseBool(v); err != nil {
+				isBool = false
+			}
+		}
+	}
+
+	switch {
+	case !sawValue:
+		return "string"
+	case isInt:
+		return "integer"
+	case isFloat:
+		return "float"
+	case isBool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// sniffCSV rejects uploads whose content doesn't look like text, since
+// http.DetectContentType can't identify CSV specifically.
+func sniffCSV(data []byte) error {
+	sample := data
+	if len(sample) > 512 {
+		sample = sample[:512]
+	}
+
+	contentType := http.DetectContentType(sample)
+	switch contentType {
+	case "text/plain; charset=utf-8", "text/csv; charset=utf-8":
+		return nil
+	default:
+		return fmt.Errorf("unsupported file type %q, expected CSV text", contentType)
+	}
+}
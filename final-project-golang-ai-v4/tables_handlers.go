@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errUnknownTable is wrapped into the error resolveTable returns for an
+// unrecognized table_id, so callers can tell a bad request apart from an
+// upstream failure.
+var errUnknownTable = errors.New("unknown table_id")
+
+// isBodyTooLarge reports whether err came from the http.MaxBytesReader
+// wrapping the request body, i.e. the client's upload exceeded maxUploadBytes.
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
+
+// resolveTable returns the table registered under tableID, or defaultTable
+// when tableID is empty.
+func resolveTable(reg *TableRegistry, tableID string, defaultTable map[string][]string) (map[string][]string, error) {
+	if tableID == "" {
+		return defaultTable, nil
+	}
+
+	table, ok := reg.Get(tableID)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errUnknownTable, tableID)
+	}
+	return table.Data, nil
+}
+
+// registerTableRoutes wires the upload/list/inspect/delete endpoints backed
+// by reg.
+func registerTableRoutes(r *gin.Engine, reg *TableRegistry) {
+	r.POST("/tables", func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadBytes)
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			if isBodyTooLarge(err) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("upload exceeds the %d byte limit", maxUploadBytes)})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file field is required"})
+			return
+		}
+		if fileHeader.Size > maxUploadBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("upload exceeds the %d byte limit", maxUploadBytes)})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		defer file.Close()
+
+		raw, err := io.ReadAll(file)
+		if err != nil {
+			if isBodyTooLarge(err) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("upload exceeds the %d byte limit", maxUploadBytes)})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := sniffCSV(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		data, err := CsvToSlice(string(raw))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		table, err := reg.Register(data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, table)
+	})
+
+	r.GET("/tables", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"tables": reg.List()})
+	})
+
+	r.GET("/tables/:id", func(c *gin.Context) {
+		table, ok := reg.Get(c.Param("id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.JSON(http.StatusOK, table)
+	})
+
+	r.DELETE("/tables/:id", func(c *gin.Context) {
+		reg.Delete(c.Param("id"))
+		c.Status(http.StatusNoContent)
+	})
+}
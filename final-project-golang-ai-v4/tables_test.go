@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestInferColumnType(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"integers", []string{"1", "2", "3"}, "integer"},
+		{"floats", []string{"1.5", "2", "3.25"}, "float"},
+		{"booleans", []string{"true", "false"}, "boolean"},
+		{"strings", []string{"alice", "bob"}, "string"},
+		{"blanks ignored", []string{"1", "", "2"}, "integer"},
+		{"all blank defaults to string", []string{"", ""}, "string"},
+		{"mixed falls back to string", []string{"1", "abc"}, "string"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := inferColumnType(tc.values); got != tc.want {
+				t.Errorf("inferColumnType(%v) = %q, want %q", tc.values, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateTableSizeRejectsTooManyRows(t *testing.T) {
+	data := map[string][]string{"col": make([]string, maxTableRows+1)}
+
+	if err := validateTableSize(data); err == nil {
+		t.Fatal("expected an error for a table over maxTableRows, got nil")
+	}
+}